@@ -0,0 +1,212 @@
+package launchpad
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// DiagramFormat identifies a supported diagram output.
+type DiagramFormat string
+
+const (
+	FormatMermaid  DiagramFormat = "mermaid"
+	FormatGraphviz DiagramFormat = "dot"
+	FormatPlantUML DiagramFormat = "plantuml"
+	defaultFormat                = FormatMermaid
+)
+
+// nodeStyle captures the per-kind styling applied to a rendered node so a
+// hierarchy with hundreds of folders/projects still reads at a glance.
+type nodeStyle struct {
+	fillColor string
+	shape     string // DOT shape / PlantUML stereotype.
+}
+
+var stylesByKind = map[string]nodeStyle{
+	Organization: {fillColor: "#4285F4", shape: "box3d"},
+	Folder:       {fillColor: "#FBBC05", shape: "folder"},
+	Project:      {fillColor: "#34A853", shape: "box"},
+}
+
+// DiagramRenderer is implemented by each supported diagram backend.
+//
+// A renderer receives a depth-first walk of the org hierarchy: BeginNode is
+// called on entering a resource, Edge for each parent->child relationship,
+// and EndNode on leaving it. Render flushes the accumulated diagram to w.
+type DiagramRenderer interface {
+	BeginNode(id, kind, label string) error
+	EndNode() error
+	Edge(parentId, childId string) error
+	Render(w io.Writer) error
+}
+
+// diagram wraps a DiagramRenderer and is threaded through the resourceHandler
+// draw methods so org/folder/project only need to know about resourceHandler,
+// not the concrete output format.
+type diagram struct {
+	renderer DiagramRenderer
+}
+
+// newDiagram returns a diagram configured for the requested format.
+func newDiagram(format DiagramFormat) (*diagram, error) {
+	switch format {
+	case FormatMermaid:
+		return &diagram{renderer: newMermaidRenderer()}, nil
+	case FormatGraphviz:
+		return &diagram{renderer: newDotRenderer()}, nil
+	case FormatPlantUML:
+		return &diagram{renderer: newPlantUMLRenderer()}, nil
+	default:
+		return nil, fmt.Errorf("unsupported diagram format %q, want one of: mermaid, dot, plantuml", format)
+	}
+}
+
+// RenderOrgDiagram draws the full org hierarchy in the requested format and
+// writes the result to w. An empty format selects Mermaid. No command-line
+// flag in this package calls it; callers pass the format directly.
+func RenderOrgDiagram(o *orgYAML, format DiagramFormat, w io.Writer) error {
+	if format == "" {
+		format = defaultFormat
+	}
+	d, err := newDiagram(format)
+	if err != nil {
+		return err
+	}
+	if err := o.draw(d); err != nil {
+		return err
+	}
+	return d.renderer.Render(w)
+}
+
+// mermaidRenderer emits a Mermaid `graph TD` flowchart.
+type mermaidRenderer struct {
+	lines     []string
+	classDefs []string
+	seenKind  map[string]bool
+}
+
+func newMermaidRenderer() *mermaidRenderer {
+	return &mermaidRenderer{seenKind: map[string]bool{}}
+}
+
+func mermaidId(id string) string {
+	return strings.NewReplacer(".", "_", "-", "_").Replace(id)
+}
+
+func (r *mermaidRenderer) BeginNode(id, kind, label string) error {
+	r.lines = append(r.lines, fmt.Sprintf("    %s[\"%s<br/>%s\"]:::%s", mermaidId(id), kind, label, strings.ToLower(kind)))
+	if !r.seenKind[kind] {
+		r.seenKind[kind] = true
+		style := stylesByKind[kind]
+		r.classDefs = append(r.classDefs, fmt.Sprintf("    classDef %s fill:%s", strings.ToLower(kind), style.fillColor))
+	}
+	return nil
+}
+
+func (r *mermaidRenderer) EndNode() error { return nil }
+
+func (r *mermaidRenderer) Edge(parentId, childId string) error {
+	r.lines = append(r.lines, fmt.Sprintf("    %s --> %s", mermaidId(parentId), mermaidId(childId)))
+	return nil
+}
+
+func (r *mermaidRenderer) Render(w io.Writer) error {
+	if _, err := fmt.Fprintln(w, "graph TD"); err != nil {
+		return err
+	}
+	for _, l := range r.lines {
+		if _, err := fmt.Fprintln(w, l); err != nil {
+			return err
+		}
+	}
+	for _, c := range r.classDefs {
+		if _, err := fmt.Fprintln(w, c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dotRenderer emits Graphviz DOT.
+type dotRenderer struct {
+	nodes []string
+	edges []string
+}
+
+func newDotRenderer() *dotRenderer { return &dotRenderer{} }
+
+func (r *dotRenderer) BeginNode(id, kind, label string) error {
+	style := stylesByKind[kind]
+	r.nodes = append(r.nodes, fmt.Sprintf("  %q [label=%q shape=%s style=filled fillcolor=%q]", id, fmt.Sprintf("%s\\n%s", kind, label), style.shape, style.fillColor))
+	return nil
+}
+
+func (r *dotRenderer) EndNode() error { return nil }
+
+func (r *dotRenderer) Edge(parentId, childId string) error {
+	r.edges = append(r.edges, fmt.Sprintf("  %q -> %q", parentId, childId))
+	return nil
+}
+
+func (r *dotRenderer) Render(w io.Writer) error {
+	if _, err := fmt.Fprintln(w, "digraph org {"); err != nil {
+		return err
+	}
+	for _, n := range r.nodes {
+		if _, err := fmt.Fprintln(w, n); err != nil {
+			return err
+		}
+	}
+	for _, e := range r.edges {
+		if _, err := fmt.Fprintln(w, e); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintln(w, "}")
+	return err
+}
+
+// plantUMLRenderer emits a PlantUML deployment-style diagram, one
+// stereotyped component per resource kind.
+type plantUMLRenderer struct {
+	nodes []string
+	edges []string
+}
+
+func newPlantUMLRenderer() *plantUMLRenderer { return &plantUMLRenderer{} }
+
+func (r *plantUMLRenderer) BeginNode(id, kind, label string) error {
+	style := stylesByKind[kind]
+	r.nodes = append(r.nodes, fmt.Sprintf("component %q as %s <<%s>> #%s", label, plantUMLAlias(id), style.shape, strings.TrimPrefix(style.fillColor, "#")))
+	return nil
+}
+
+func (r *plantUMLRenderer) EndNode() error { return nil }
+
+func (r *plantUMLRenderer) Edge(parentId, childId string) error {
+	r.edges = append(r.edges, fmt.Sprintf("%s --> %s", plantUMLAlias(parentId), plantUMLAlias(childId)))
+	return nil
+}
+
+func plantUMLAlias(id string) string {
+	return strings.NewReplacer(".", "_", "-", "_").Replace(id)
+}
+
+func (r *plantUMLRenderer) Render(w io.Writer) error {
+	if _, err := fmt.Fprintln(w, "@startuml"); err != nil {
+		return err
+	}
+	for _, n := range r.nodes {
+		if _, err := fmt.Fprintln(w, n); err != nil {
+			return err
+		}
+	}
+	for _, e := range r.edges {
+		if _, err := fmt.Fprintln(w, e); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintln(w, "@enduml")
+	return err
+}
@@ -0,0 +1,40 @@
+package launchpad
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// TestMermaidRendererDrawsNodesAndEdges checks the core contract every
+// DiagramRenderer must satisfy: a node rendered for each BeginNode/EndNode
+// pair, plus an edge line for each Edge call, in a recognizable format.
+func TestMermaidRendererDrawsNodesAndEdges(t *testing.T) {
+	r := newMermaidRenderer()
+	if err := r.BeginNode(Organization+".o1", Organization, "root org"); err != nil {
+		t.Fatalf("BeginNode: %v", err)
+	}
+	if err := r.EndNode(); err != nil {
+		t.Fatalf("EndNode: %v", err)
+	}
+	if err := r.BeginNode(Folder+".platform", Folder, "Platform Team"); err != nil {
+		t.Fatalf("BeginNode: %v", err)
+	}
+	if err := r.EndNode(); err != nil {
+		t.Fatalf("EndNode: %v", err)
+	}
+	if err := r.Edge(Organization+".o1", Folder+".platform"); err != nil {
+		t.Fatalf("Edge: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := r.Render(&buf); err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{"graph TD", "Organization_o1", "Folder_platform", "Organization_o1 --> Folder_platform"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("Render output missing %q:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,246 @@
+package launchpad
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// folderSpecYAML defines a Folder's Spec.
+type folderSpecYAML struct {
+	Id             string             `yaml:"id"`
+	DisplayName    string             `yaml:"displayName"`
+	SubFolderSpecs []*folderSpecYAML  `yaml:"folders,omitempty"`
+	ProjectSpecs   []*projectSpecYAML `yaml:"projects,omitempty"`
+
+	Labels    map[string]string `yaml:"labels,omitempty"`
+	IamPolicy []iamBindingYAML  `yaml:"iamPolicy,omitempty"`
+
+	// ParentRef optionally declares this folder's logical parent by
+	// kind+id, independent of where the folder is physically nested in the
+	// spec tree. It lets the assembler catch a parentage cycle (e.g. two
+	// folders nested as siblings that each declare the other as parent)
+	// that plain YAML nesting can never produce on its own.
+	ParentRef *referenceYAML `yaml:"parentRef,omitempty"`
+
+	// ProvSourceFile and ProvSignedBy are stamped by include.go's
+	// stampNestedProvenance as this spec is merged into its including
+	// document, recording which physical file it actually came from (and
+	// who signed that file). Never set by a human-authored spec; exported
+	// only so yaml.Unmarshal can populate them from the injected keys.
+	ProvSourceFile string `yaml:"__sourceFile,omitempty"`
+	ProvSignedBy   string `yaml:"__signedBy,omitempty"`
+}
+
+// folderYAML represents a GCP folder.
+type folderYAML struct {
+	headerYAML `yaml:",inline"`
+	Spec       folderSpecYAML `yaml:"spec"`
+
+	parentKind string // kind of the resource this folder was declared under.
+	parentId   string // id of the resource this folder was declared under.
+
+	subFolders folders  // subFolders represents validated sub folders.
+	projects   projects // projects represents validated child projects.
+
+	// effectiveLabels and effectiveIamPolicy are Spec.Labels/Spec.IamPolicy
+	// plus whatever was inherited from the parent via inheritFields.
+	effectiveLabels    map[string]string
+	effectiveIamPolicy []iamBindingYAML
+	fieldProv          *fieldProvenance
+
+	srcFile string // input file this folder's spec was loaded from.
+	signer  string // PGP key id that verifiably authored this spec, if any.
+}
+
+// folders is an ordered collection of folderYAML, such as an org or
+// folder's immediate children.
+type folders []*folderYAML
+
+// add appends f to the collection.
+func (fs *folders) add(f *folderYAML) { *fs = append(*fs, f) }
+
+// newSubFoldersBySpecs constructs folderYAML values for specs, recording
+// parentKind/parentId so each folder knows where it was declared.
+func newSubFoldersBySpecs(specs []*folderSpecYAML, parentKind, parentId string) folders {
+	fs := make(folders, 0, len(specs))
+	for _, spec := range specs {
+		f := &folderYAML{Spec: *spec, parentKind: parentKind, parentId: parentId, srcFile: spec.ProvSourceFile, signer: spec.ProvSignedBy}
+		f.subFolders = newSubFoldersBySpecs(spec.SubFolderSpecs, Folder, spec.Id)
+		f.projects = newProjectsBySpecs(spec.ProjectSpecs, Folder, spec.Id)
+		fs.add(f)
+	}
+	return fs
+}
+
+// resId returns an internal referencable id.
+func (f *folderYAML) resId() string { return fmt.Sprintf("%s.%s", Folder, f.Spec.Id) }
+
+// validate ensures input YAML fields are correct.
+func (f *folderYAML) validate() error {
+	if f.Spec.Id == "" {
+		return errValidationFailed
+	}
+	return nil
+}
+
+// inheritFields merges parentLabels/parentBindings into f's effective
+// labels and IAM policy, recording provenance for every inherited entry.
+// f's own Spec.Labels/Spec.IamPolicy always take precedence.
+func (f *folderYAML) inheritFields(parentLabels map[string]string, parentBindings []iamBindingYAML, parentResId string) {
+	f.effectiveLabels = cloneLabels(f.Spec.Labels)
+	f.effectiveIamPolicy = append([]iamBindingYAML{}, f.Spec.IamPolicy...)
+	f.fieldProv = newFieldProvenance(len(f.effectiveIamPolicy))
+	f.fieldProv.inheritFrom(parentResId, parentLabels, parentBindings, &f.effectiveLabels, &f.effectiveIamPolicy)
+}
+
+// addToOrg adds the folder into the assembled organization.
+//
+// addToOrg also recursively adds the folder's subFolders and projects into
+// the org.
+func (f *folderYAML) addToOrg(ao *assembledOrg) error {
+	if err := ao.registerResource(f); err != nil {
+		return err
+	}
+
+	for _, sf := range f.subFolders {
+		sf.inheritFields(f.effectiveLabels, f.effectiveIamPolicy, f.resId())
+		if err := sf.addToOrg(ao); err != nil {
+			return err
+		}
+	}
+	for _, p := range f.projects {
+		p.inheritFields(f.effectiveLabels, f.effectiveIamPolicy, f.resId())
+		if err := p.addToOrg(ao); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resolveReferences processes references to this folder.
+//
+// resolveReferences takes reference from a folder or project as a child of
+// this folder.
+func (f *folderYAML) resolveReferences(refs []resourceHandler) error {
+	for _, ref := range refs {
+		switch r := ref.(type) {
+		case *folderYAML:
+			f.subFolders.add(r)
+		case *projectYAML:
+			f.projects.add(r)
+		default:
+			return fmt.Errorf("unable to process reference from resource %T", ref)
+		}
+	}
+	return nil
+}
+
+// initializeByRef initializes a folder through another resource's
+// reference.
+func (f *folderYAML) initializeByRef(ref *referenceYAML) error {
+	if f.Spec.Id != "" && f.Spec.Id != ref.TargetId {
+		return errConflictId
+	}
+	f.Spec.Id = ref.TargetId
+	return nil
+}
+
+// rollbackInit resets the folder to its pre-initialization state.
+func (f *folderYAML) rollbackInit() { f.Spec.Id = "" }
+
+// parentRef returns the folder's declared logical parent, if any.
+func (f *folderYAML) parentRef() *referenceYAML { return f.Spec.ParentRef }
+
+// sourceFile returns the input file this folder's spec was loaded from.
+func (f *folderYAML) sourceFile() string { return f.srcFile }
+
+// setSourceFile records path as the folder's input file, unless the folder
+// already knows its own source file (stamped at construction time from the
+// include graph, see newSubFoldersBySpecs). It then recurses into every
+// subFolder/project with the same fallback, so a resource actually declared
+// in an included file keeps that file's provenance rather than inheriting
+// the root document's.
+func (f *folderYAML) setSourceFile(path string) {
+	if f.srcFile == "" {
+		f.srcFile = path
+	}
+	for _, sf := range f.subFolders {
+		sf.setSourceFile(path)
+	}
+	for _, p := range f.projects {
+		p.setSourceFile(path)
+	}
+}
+
+// signedBy returns the PGP key id that verifiably authored this folder's
+// spec.
+func (f *folderYAML) signedBy() string { return f.signer }
+
+// setSignedBy records keyId as the folder's verified signer, unless the
+// folder already has its own signer (stamped at construction time from the
+// include graph), then recurses into every subFolder/project with the same
+// fallback. See setSourceFile.
+func (f *folderYAML) setSignedBy(keyId string) {
+	if f.signer == "" {
+		f.signer = keyId
+	}
+	for _, sf := range f.subFolders {
+		sf.setSignedBy(keyId)
+	}
+	for _, p := range f.projects {
+		p.setSignedBy(keyId)
+	}
+}
+
+// dump writes the folder's string representation into buff.
+func (f *folderYAML) dump(ind int, buff io.Writer) error {
+	indent := strings.Repeat(" ", ind)
+	if _, err := fmt.Fprintf(buff, "%s%s.%s (\"%s\")\n", indent, Folder, f.Spec.Id, f.Spec.DisplayName); err != nil {
+		return err
+	}
+	if err := dumpFields(ind+defaultIndentSize, buff, f.effectiveLabels, f.effectiveIamPolicy, f.fieldProv); err != nil {
+		return err
+	}
+	if err := dumpSigner(ind+defaultIndentSize, buff, f.resId(), f.signer); err != nil {
+		return err
+	}
+
+	for _, sf := range f.subFolders {
+		if err := sf.dump(ind+defaultIndentSize, buff); err != nil {
+			return err
+		}
+	}
+	for _, p := range f.projects {
+		if err := p.dump(ind+defaultIndentSize, buff); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// draw renders the folder as a node of the diagram and recurses into its
+// subFolders and projects.
+func (f *folderYAML) draw(d *diagram) error {
+	if err := d.renderer.BeginNode(f.resId(), Folder, f.Spec.DisplayName); err != nil {
+		return err
+	}
+
+	for _, sf := range f.subFolders {
+		if err := d.renderer.Edge(f.resId(), sf.resId()); err != nil {
+			return err
+		}
+		if err := sf.draw(d); err != nil {
+			return err
+		}
+	}
+	for _, p := range f.projects {
+		if err := d.renderer.Edge(f.resId(), p.resId()); err != nil {
+			return err
+		}
+		if err := p.draw(d); err != nil {
+			return err
+		}
+	}
+	return d.renderer.EndNode()
+}
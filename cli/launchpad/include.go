@@ -0,0 +1,282 @@
+package launchpad
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// includeKey is the top-level directive recognized by the loader. Paths are
+// resolved relative to the including file and may contain globs.
+const includeKey = "include"
+
+// includeCycleError reports an include chain that revisits a file it has
+// already started loading, e.g. A -> B -> A.
+type includeCycleError struct {
+	chain []string
+}
+
+func (e *includeCycleError) Error() string {
+	return fmt.Sprintf("include cycle detected: %s", strings.Join(e.chain, " -> "))
+}
+
+// mergeConflictError reports two files that set the same scalar key to
+// different values.
+type mergeConflictError struct {
+	key          string
+	pathA, pathB string
+	valA, valB   interface{}
+}
+
+func (e *mergeConflictError) Error() string {
+	return fmt.Sprintf("conflicting value for %q: %q sets %v, %q sets %v", e.key, e.pathA, e.valA, e.pathB, e.valB)
+}
+
+// LoadSpec reads the YAML document at path, verifying detached signatures
+// (if opts.Verify.TrustedKeys is configured), expanding any inputs:
+// template directive, and recursively resolving any top-level `include:`
+// directive. It returns the single merged document as a generic tree ready
+// to be unmarshaled into orgYAML, plus the verified signer of path itself.
+func LoadSpec(path string, opts TemplateOptions) (map[string]interface{}, string, error) {
+	vs, err := newVerifyState(opts.Verify)
+	if err != nil {
+		return nil, "", err
+	}
+	merged, err := loadAndMerge(path, nil, opts, vs)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := vs.err(); err != nil {
+		return nil, "", err
+	}
+	return merged, vs.signedBy[path], nil
+}
+
+func loadAndMerge(path string, stack []string, opts TemplateOptions, vs *verifyState) (map[string]interface{}, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range stack {
+		if p == abs {
+			return nil, &includeCycleError{chain: append(append([]string{}, stack...), abs)}
+		}
+	}
+	stack = append(stack, abs)
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	expanded, err := ExpandSpec(path, raw, opts)
+	if err != nil {
+		return nil, err
+	}
+	var doc map[string]interface{}
+	if err := yaml.Unmarshal(expanded, &doc); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	if doc == nil {
+		doc = map[string]interface{}{}
+	}
+	kind, _ := doc["kind"].(string)
+	vs.verify(path, raw, kind)
+	if spec, ok := doc["spec"].(map[string]interface{}); ok {
+		stampNestedProvenance(spec, path, vs.signedBy[path])
+	}
+
+	includes, err := includePatterns(doc)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	delete(doc, includeKey)
+
+	merged := doc
+	mergedFrom := path
+	for _, pattern := range includes {
+		matches, err := filepath.Glob(filepath.Join(filepath.Dir(path), pattern))
+		if err != nil {
+			return nil, fmt.Errorf("%s: invalid include pattern %q: %w", path, pattern, err)
+		}
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("%s: include pattern %q matched no files", path, pattern)
+		}
+		sort.Strings(matches)
+		for _, match := range matches {
+			included, err := loadAndMerge(match, stack, opts, vs)
+			if err != nil {
+				return nil, err
+			}
+			mv, err := mergeValue(merged, included, "", mergedFrom, match)
+			if err != nil {
+				return nil, err
+			}
+			merged = mv.(map[string]interface{})
+			mergedFrom = match
+		}
+	}
+	return merged, nil
+}
+
+// includePatterns extracts and validates the `include:` directive, if any.
+func includePatterns(doc map[string]interface{}) ([]string, error) {
+	v, ok := doc[includeKey]
+	if !ok {
+		return nil, nil
+	}
+	list, ok := v.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%q must be a list of paths", includeKey)
+	}
+	patterns := make([]string, 0, len(list))
+	for _, e := range list {
+		s, ok := e.(string)
+		if !ok {
+			return nil, fmt.Errorf("%q entries must be strings, got %T", includeKey, e)
+		}
+		patterns = append(patterns, s)
+	}
+	return patterns, nil
+}
+
+// mergeValue deep-merges b into a: maps are merged key-by-key, resource
+// sequences are concatenated and deduplicated by resId, and scalars must
+// agree or the merge fails naming both source paths.
+func mergeValue(a, b interface{}, key, pathA, pathB string) (interface{}, error) {
+	if a == nil {
+		return b, nil
+	}
+	if b == nil {
+		return a, nil
+	}
+
+	switch av := a.(type) {
+	case map[string]interface{}:
+		bv, ok := b.(map[string]interface{})
+		if !ok {
+			return nil, &mergeConflictError{key: key, pathA: pathA, pathB: pathB, valA: a, valB: b}
+		}
+		return mergeMaps(av, bv, key, pathA, pathB)
+	case []interface{}:
+		bv, ok := b.([]interface{})
+		if !ok {
+			return nil, &mergeConflictError{key: key, pathA: pathA, pathB: pathB, valA: a, valB: b}
+		}
+		return mergeSequences(av, bv, key, pathA, pathB)
+	default:
+		if a == b {
+			return a, nil
+		}
+		return nil, &mergeConflictError{key: key, pathA: pathA, pathB: pathB, valA: a, valB: b}
+	}
+}
+
+func mergeMaps(a, b map[string]interface{}, keyPrefix, pathA, pathB string) (map[string]interface{}, error) {
+	out := make(map[string]interface{}, len(a))
+	for k, v := range a {
+		out[k] = v
+	}
+	for k, bv := range b {
+		childKey := k
+		if keyPrefix != "" {
+			childKey = keyPrefix + "." + k
+		}
+		av, ok := out[k]
+		if !ok {
+			out[k] = bv
+			continue
+		}
+		merged, err := mergeValue(av, bv, childKey, pathA, pathB)
+		if err != nil {
+			return nil, err
+		}
+		out[k] = merged
+	}
+	return out, nil
+}
+
+// mergeSequences concatenates a and b. Elements that look like resources
+// (maps with a `kind` and `spec.id`) are deduplicated by resId, deep-merging
+// duplicates; all other elements are appended as-is.
+func mergeSequences(a, b []interface{}, keyPrefix, pathA, pathB string) ([]interface{}, error) {
+	out := append([]interface{}{}, a...)
+	indexByResId := map[string]int{}
+	for i, item := range out {
+		if id, ok := rawResId(item); ok {
+			indexByResId[id] = i
+		}
+	}
+
+	for _, item := range b {
+		id, ok := rawResId(item)
+		if !ok {
+			out = append(out, item)
+			continue
+		}
+		if idx, exists := indexByResId[id]; exists {
+			merged, err := mergeValue(out[idx], item, fmt.Sprintf("%s[%s]", keyPrefix, id), pathA, pathB)
+			if err != nil {
+				return nil, err
+			}
+			out[idx] = merged
+			continue
+		}
+		indexByResId[id] = len(out)
+		out = append(out, item)
+	}
+	return out, nil
+}
+
+// stampNestedProvenance records path and signer (this file's own verified
+// signer, possibly "") onto every folder/project spec declared directly in
+// spec.folders/spec.projects, recursing into their own nested folders/
+// projects. It runs once per file, before that file's document is merged
+// with anything else, so the stamp always reflects the file that actually
+// declared the resource rather than whichever document it ends up merged
+// into. orgYAML/folderYAML/projectYAML read these stamps back out via
+// folderSpecYAML.ProvSourceFile/ProvSignedBy (see newSubFoldersBySpecs and
+// newProjectsBySpecs) instead of inheriting the root document's source file
+// and signer the way setSourceFile/setSignedBy's fallback does.
+func stampNestedProvenance(spec map[string]interface{}, path, signer string) {
+	for _, key := range []string{"folders", "projects"} {
+		list, ok := spec[key].([]interface{})
+		if !ok {
+			continue
+		}
+		for _, item := range list {
+			m, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			m["__sourceFile"] = path
+			if signer != "" {
+				m["__signedBy"] = signer
+			}
+			stampNestedProvenance(m, path, signer)
+		}
+	}
+}
+
+// rawResId extracts "<Kind>.<id>" from a resource-shaped map, matching the
+// resId() convention of orgYAML/folderYAML/projectYAML, without having to
+// unmarshal into those types first.
+func rawResId(v interface{}) (string, bool) {
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return "", false
+	}
+	kind, _ := m["kind"].(string)
+	spec, ok := m["spec"].(map[string]interface{})
+	if !ok || kind == "" {
+		return "", false
+	}
+	id, _ := spec["id"].(string)
+	if id == "" {
+		return "", false
+	}
+	return kind + "." + id, true
+}
@@ -0,0 +1,55 @@
+package launchpad
+
+import "testing"
+
+// TestMergeMapsConcatenatesFolderLists exercises the case the whole include:
+// mechanism exists for: two files each contributing folders under the same
+// spec.folders list end up concatenated into one merged document.
+func TestMergeMapsConcatenatesFolderLists(t *testing.T) {
+	a := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"id": "o1",
+			"folders": []interface{}{
+				map[string]interface{}{"id": "a"},
+			},
+		},
+	}
+	b := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"folders": []interface{}{
+				map[string]interface{}{"id": "b"},
+			},
+		},
+	}
+
+	merged, err := mergeValue(a, b, "", "a.yaml", "b.yaml")
+	if err != nil {
+		t.Fatalf("mergeValue: %v", err)
+	}
+
+	spec := merged.(map[string]interface{})["spec"].(map[string]interface{})
+	if spec["id"] != "o1" {
+		t.Fatalf("spec.id = %v, want o1", spec["id"])
+	}
+	folders := spec["folders"].([]interface{})
+	if len(folders) != 2 {
+		t.Fatalf("spec.folders = %v, want 2 entries", folders)
+	}
+}
+
+// TestMergeValueConflictingScalarErrors confirms a genuine disagreement
+// between two files on the same scalar key fails loudly, naming both
+// source paths, rather than silently picking one.
+func TestMergeValueConflictingScalarErrors(t *testing.T) {
+	a := map[string]interface{}{"spec": map[string]interface{}{"id": "o1"}}
+	b := map[string]interface{}{"spec": map[string]interface{}{"id": "o2"}}
+
+	_, err := mergeValue(a, b, "", "a.yaml", "b.yaml")
+	conflict, ok := err.(*mergeConflictError)
+	if !ok {
+		t.Fatalf("mergeValue: expected *mergeConflictError, got %T: %v", err, err)
+	}
+	if conflict.pathA != "a.yaml" || conflict.pathB != "b.yaml" {
+		t.Fatalf("conflict = %+v, want pathA=a.yaml pathB=b.yaml", conflict)
+	}
+}
@@ -0,0 +1,60 @@
+package launchpad
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LoadOrg reads the org spec at path, verifying detached signatures (unless
+// opts.Verify.InsecureSkipVerify is set), expanding inputs: templating,
+// resolving any include: directive, and applying the resIdRenames config.
+// It returns the validated org tree plus the renames so they can be handed
+// to the assembledOrg that registers it.
+func LoadOrg(path string, opts TemplateOptions) (*orgYAML, map[string]string, error) {
+	merged, signer, err := LoadSpec(path, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	renames, err := extractResIdRenames(merged)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	raw, err := yaml.Marshal(merged)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: re-encoding merged spec: %w", path, err)
+	}
+	var o orgYAML
+	if err := yaml.Unmarshal(raw, &o); err != nil {
+		return nil, nil, fmt.Errorf("%s: unmarshaling merged spec: %w", path, err)
+	}
+	if err := o.validate(); err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", path, err)
+	}
+	o.setSourceFile(path)
+	o.setSignedBy(signer)
+
+	return &o, renames, nil
+}
+
+// Assemble builds an assembledOrg from the org spec at path, applying its
+// resIdRenames config and running the full addToOrg / reference-resolution
+// pipeline.
+func Assemble(path string, opts TemplateOptions) (*assembledOrg, error) {
+	o, renames, err := LoadOrg(path, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	ao := newAssembledOrg()
+	ao.SetResIdRenames(renames)
+	if err := o.addToOrg(ao); err != nil {
+		return nil, err
+	}
+	if err := ao.resolveAndCheckReferences(); err != nil {
+		return nil, err
+	}
+	return ao, nil
+}
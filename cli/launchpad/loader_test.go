@@ -0,0 +1,113 @@
+package launchpad
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/ProtonMail/go-crypto/openpgp/packet"
+)
+
+// newTestSigner generates a throwaway PGP entity for signing test fixtures.
+// RSABits is kept small since these tests only care about signature
+// plumbing, not cryptographic strength.
+func newTestSigner(t *testing.T, name string) *openpgp.Entity {
+	t.Helper()
+	e, err := openpgp.NewEntity(name, "", name+"@example.com", &packet.Config{RSABits: 1024, MinRSABits: 1024})
+	if err != nil {
+		t.Fatalf("NewEntity(%s): %v", name, err)
+	}
+	return e
+}
+
+// armoredPublicKey returns signer's armored public key, as it would appear
+// in a trustedKeys entry's publicKey field.
+func armoredPublicKey(t *testing.T, signer *openpgp.Entity) string {
+	t.Helper()
+	var buf bytes.Buffer
+	w, err := armor.Encode(&buf, "PGP PUBLIC KEY BLOCK", nil)
+	if err != nil {
+		t.Fatalf("armor.Encode: %v", err)
+	}
+	if err := signer.Serialize(w); err != nil {
+		t.Fatalf("Serialize: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("close armor writer: %v", err)
+	}
+	return buf.String()
+}
+
+// signFile writes raw to path, then writes path+".sig" as its detached
+// signature by signer.
+func signFile(t *testing.T, path string, raw []byte, signer *openpgp.Entity) {
+	t.Helper()
+	if err := os.WriteFile(path, raw, 0o644); err != nil {
+		t.Fatalf("WriteFile(%s): %v", path, err)
+	}
+	var sigBuf bytes.Buffer
+	if err := openpgp.ArmoredDetachSign(&sigBuf, signer, bytes.NewReader(raw), nil); err != nil {
+		t.Fatalf("ArmoredDetachSign(%s): %v", path, err)
+	}
+	if err := os.WriteFile(path+".sig", sigBuf.Bytes(), 0o644); err != nil {
+		t.Fatalf("WriteFile(%s.sig): %v", path, err)
+	}
+}
+
+// TestLoadOrgAttributesNestedResourceToItsOwnIncludeFile reproduces the
+// reviewer's repro: a root org spec signed by one key includes a second
+// file, signed by a different key, that declares a folder. The folder must
+// be attributed to its own file's signer, not the root's.
+func TestLoadOrgAttributesNestedResourceToItsOwnIncludeFile(t *testing.T) {
+	dir := t.TempDir()
+	keyA := newTestSigner(t, "root")
+	keyB := newTestSigner(t, "team-b")
+
+	teamBPath := filepath.Join(dir, "teamB.yaml")
+	teamBRaw := []byte(`apiVersion: v1
+kind: Organization
+spec:
+  folders:
+    - id: platform
+      displayName: Platform Team
+`)
+	signFile(t, teamBPath, teamBRaw, keyB)
+
+	rootPath := filepath.Join(dir, "org.yaml")
+	rootRaw := []byte(`apiVersion: v1
+kind: Organization
+include:
+  - teamB.yaml
+spec:
+  id: o1
+  displayName: root org
+`)
+	signFile(t, rootPath, rootRaw, keyA)
+
+	opts := TemplateOptions{Verify: VerifyOptions{TrustedKeys: []trustedKey{
+		{KeyId: keyA.PrimaryKey.KeyIdString(), PublicKey: armoredPublicKey(t, keyA)},
+		{KeyId: keyB.PrimaryKey.KeyIdString(), PublicKey: armoredPublicKey(t, keyB)},
+	}}}
+
+	o, _, err := LoadOrg(rootPath, opts)
+	if err != nil {
+		t.Fatalf("LoadOrg: %v", err)
+	}
+
+	if got, want := o.signedBy(), keyA.PrimaryKey.KeyIdString(); got != want {
+		t.Fatalf("org signedBy() = %q, want %q", got, want)
+	}
+	if len(o.subFolders) != 1 {
+		t.Fatalf("subFolders = %v, want exactly one folder", o.subFolders)
+	}
+	platform := o.subFolders[0]
+	if got, want := platform.signedBy(), keyB.PrimaryKey.KeyIdString(); got != want {
+		t.Fatalf("Folder.platform signedBy() = %q, want %q (teamB.yaml's signer, not the root's)", got, want)
+	}
+	if got := platform.sourceFile(); got != teamBPath {
+		t.Fatalf("Folder.platform sourceFile() = %q, want %q", got, teamBPath)
+	}
+}
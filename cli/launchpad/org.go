@@ -15,6 +15,9 @@ type orgSpecYAML struct {
 	Id             string            `yaml:"id"`          // GCP organization id.
 	DisplayName    string            `yaml:"displayName"` // Optional field to denote GCP organization name.
 	SubFolderSpecs []*folderSpecYAML `yaml:"folders"`
+
+	Labels    map[string]string `yaml:"labels,omitempty"`
+	IamPolicy []iamBindingYAML  `yaml:"iamPolicy,omitempty"`
 }
 
 // orgYAML represents a GCP organization.
@@ -22,6 +25,16 @@ type orgYAML struct {
 	headerYAML `yaml:",inline"`
 	Spec       orgSpecYAML `yaml:"spec"`
 	subFolders folders     // subFolder represents validated sub directories.
+
+	// effectiveLabels and effectiveIamPolicy are Spec.Labels/Spec.IamPolicy
+	// as-is: the org is the root of the hierarchy, so it has nothing to
+	// inherit from.
+	effectiveLabels    map[string]string
+	effectiveIamPolicy []iamBindingYAML
+	fieldProv          *fieldProvenance
+
+	srcFile string // input file this org's spec was loaded from.
+	signer  string // PGP key id that verifiably authored this spec, if any.
 }
 
 // resId returns an internal referencable id.
@@ -36,6 +49,9 @@ func (o *orgYAML) validate() error {
 	}
 
 	o.subFolders = newSubFoldersBySpecs(o.Spec.SubFolderSpecs, Organization, o.Spec.Id)
+	o.effectiveLabels = cloneLabels(o.Spec.Labels)
+	o.effectiveIamPolicy = append([]iamBindingYAML{}, o.Spec.IamPolicy...)
+	o.fieldProv = newFieldProvenance(len(o.Spec.IamPolicy))
 	return nil
 }
 
@@ -43,18 +59,12 @@ func (o *orgYAML) validate() error {
 //
 // addToOrg also recursively add organization's subFolders into the org.
 func (o *orgYAML) addToOrg(ao *assembledOrg) error {
-	// assembledOrg.org could have already been initialized by others via reference, or explicitly
-	// need to copy all fields over
-	if err := o.mergeFields(&ao.org); err != nil {
-		return err
-	}
-	ao.org = *o // replace finalized org as the current org.
-
-	if err := ao.registerResource(o, nil); err != nil {
+	if err := ao.registerResource(o); err != nil {
 		return err
 	}
 
 	for _, sf := range o.subFolders { // Recursively enroll sub-folders
+		sf.inheritFields(o.effectiveLabels, o.effectiveIamPolicy, o.resId())
 		if err := sf.addToOrg(ao); err != nil {
 			return err
 		}
@@ -90,21 +100,39 @@ func (o *orgYAML) initializeByRef(ref *referenceYAML) error {
 	return nil
 }
 
-// mergeFields merges all fields from input to current resource.
-//
-// mergeFields is NOT recursive. However, future version can consider recursively merging
-// all sub resources through additional of mergeFields requirement in resourceHandler.
-func (o *orgYAML) mergeFields(oldO *orgYAML) error {
-	if oldO.APIVersion != "" {
-		o.APIVersion = oldO.APIVersion
+// rollbackInit resets the org to its pre-initialization state.
+func (o *orgYAML) rollbackInit() { o.Spec.Id = "" }
+
+// parentRef always returns nil: the org is the root of the hierarchy and
+// never declares a parent.
+func (o *orgYAML) parentRef() *referenceYAML { return nil }
+
+// sourceFile returns the input file this org's spec was loaded from.
+func (o *orgYAML) sourceFile() string { return o.srcFile }
+
+// setSourceFile records path as the input file for the org, then recurses
+// into every subFolder/project with the same path as a fallback: a
+// subFolder/project declared in an included file already knows its own
+// source file (stamped at construction time, see newSubFoldersBySpecs) and
+// keeps it; setSourceFile only fills in path for a node that has none.
+func (o *orgYAML) setSourceFile(path string) {
+	o.srcFile = path
+	for _, sf := range o.subFolders {
+		sf.setSourceFile(path)
 	}
-	if oldO.Spec.DisplayName != "" {
-		o.APIVersion = oldO.Spec.DisplayName
+}
+
+// signedBy returns the PGP key id that verifiably authored this org's spec.
+func (o *orgYAML) signedBy() string { return o.signer }
+
+// setSignedBy records keyId as the org's verified signer, then recurses
+// into every subFolder/project with the same fallback as setSourceFile: a
+// node stamped with its own signer from the include graph keeps it.
+func (o *orgYAML) setSignedBy(keyId string) {
+	o.signer = keyId
+	for _, sf := range o.subFolders {
+		sf.setSignedBy(keyId)
 	}
-	// TODO (FR) recursively merge folderSpecYAML projectSpecYAML ...etc
-	// resolveReferences ensures output linkage is valid, hence not a priority as this is a cleanup.
-	// downside is {resource}SpecYAML sub-resources are misaligned.
-	return nil
 }
 
 // dump writes resource's string representation into provided buffer.
@@ -114,6 +142,12 @@ func (o *orgYAML) dump(ind int, buff io.Writer) error {
 	if err != nil {
 		return err
 	}
+	if err := dumpFields(ind+defaultIndentSize, buff, o.effectiveLabels, o.effectiveIamPolicy, o.fieldProv); err != nil {
+		return err
+	}
+	if err := dumpSigner(ind+defaultIndentSize, buff, o.resId(), o.signer); err != nil {
+		return err
+	}
 
 	for _, sf := range o.subFolders {
 		err = sf.dump(ind+defaultIndentSize, buff)
@@ -124,19 +158,21 @@ func (o *orgYAML) dump(ind int, buff io.Writer) error {
 	return nil
 }
 
-// draw adds the org to a diagram
+// draw renders the org as the root node of the diagram and recurses into
+// subFolders so the full Organization -> Folder -> ... -> Project hierarchy
+// is drawn.
 func (o *orgYAML) draw(d *diagram) error {
-	indent := strings.Repeat(" ", ind)
-	_, err := fmt.Fprintf(buff, "%s%s.%s (\"%s\")\n", indent, Organization, o.Spec.Id, o.Spec.DisplayName)
-	if err != nil {
+	if err := d.renderer.BeginNode(o.resId(), Organization, o.Spec.DisplayName); err != nil {
 		return err
 	}
 
 	for _, sf := range o.subFolders {
-		err = sf.dump(ind+defaultIndentSize, buff)
-		if err != nil {
+		if err := d.renderer.Edge(o.resId(), sf.resId()); err != nil {
+			return err
+		}
+		if err := sf.draw(d); err != nil {
 			return err
 		}
 	}
-	return nil
+	return d.renderer.EndNode()
 }
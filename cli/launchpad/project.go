@@ -0,0 +1,160 @@
+package launchpad
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// projectSpecYAML defines a Project's Spec.
+type projectSpecYAML struct {
+	Id          string `yaml:"id"`
+	DisplayName string `yaml:"displayName"`
+
+	Labels    map[string]string `yaml:"labels,omitempty"`
+	IamPolicy []iamBindingYAML  `yaml:"iamPolicy,omitempty"`
+
+	// ParentRef optionally declares this project's logical parent by
+	// kind+id, independent of where the project is physically nested in
+	// the spec tree. See folderSpecYAML.ParentRef.
+	ParentRef *referenceYAML `yaml:"parentRef,omitempty"`
+
+	// ProvSourceFile and ProvSignedBy are stamped by include.go's
+	// stampNestedProvenance. See folderSpecYAML.
+	ProvSourceFile string `yaml:"__sourceFile,omitempty"`
+	ProvSignedBy   string `yaml:"__signedBy,omitempty"`
+}
+
+// projectYAML represents a GCP project.
+type projectYAML struct {
+	headerYAML `yaml:",inline"`
+	Spec       projectSpecYAML `yaml:"spec"`
+
+	parentKind string // kind of the resource this project was declared under.
+	parentId   string // id of the resource this project was declared under.
+
+	// effectiveLabels and effectiveIamPolicy are Spec.Labels/Spec.IamPolicy
+	// plus whatever was inherited from the parent via inheritFields.
+	effectiveLabels    map[string]string
+	effectiveIamPolicy []iamBindingYAML
+	fieldProv          *fieldProvenance
+
+	srcFile string // input file this project's spec was loaded from.
+	signer  string // PGP key id that verifiably authored this spec, if any.
+}
+
+// projects is an ordered collection of projectYAML, such as a folder's
+// immediate child projects.
+type projects []*projectYAML
+
+// add appends p to the collection.
+func (ps *projects) add(p *projectYAML) { *ps = append(*ps, p) }
+
+// newProjectsBySpecs constructs projectYAML values for specs, recording
+// parentKind/parentId so each project knows where it was declared.
+func newProjectsBySpecs(specs []*projectSpecYAML, parentKind, parentId string) projects {
+	ps := make(projects, 0, len(specs))
+	for _, spec := range specs {
+		ps.add(&projectYAML{Spec: *spec, parentKind: parentKind, parentId: parentId, srcFile: spec.ProvSourceFile, signer: spec.ProvSignedBy})
+	}
+	return ps
+}
+
+// resId returns an internal referencable id.
+func (p *projectYAML) resId() string { return fmt.Sprintf("%s.%s", Project, p.Spec.Id) }
+
+// validate ensures input YAML fields are correct.
+func (p *projectYAML) validate() error {
+	if p.Spec.Id == "" {
+		return errValidationFailed
+	}
+	return nil
+}
+
+// inheritFields merges parentLabels/parentBindings into p's effective
+// labels and IAM policy, recording provenance for every inherited entry.
+// p's own Spec.Labels/Spec.IamPolicy always take precedence.
+func (p *projectYAML) inheritFields(parentLabels map[string]string, parentBindings []iamBindingYAML, parentResId string) {
+	p.effectiveLabels = cloneLabels(p.Spec.Labels)
+	p.effectiveIamPolicy = append([]iamBindingYAML{}, p.Spec.IamPolicy...)
+	p.fieldProv = newFieldProvenance(len(p.effectiveIamPolicy))
+	p.fieldProv.inheritFrom(parentResId, parentLabels, parentBindings, &p.effectiveLabels, &p.effectiveIamPolicy)
+}
+
+// addToOrg adds the project into the assembled organization.
+//
+// Projects are leaves in the hierarchy, so addToOrg does not recurse.
+func (p *projectYAML) addToOrg(ao *assembledOrg) error {
+	return ao.registerResource(p)
+}
+
+// resolveReferences accepts no references for projects: projects have no
+// children that can reference them into the hierarchy, so any non-empty
+// refs is an error.
+func (p *projectYAML) resolveReferences(refs []resourceHandler) error {
+	if len(refs) > 0 {
+		return fmt.Errorf("project %s cannot have child references", p.resId())
+	}
+	return nil
+}
+
+// initializeByRef initializes a project through another resource's
+// reference.
+func (p *projectYAML) initializeByRef(ref *referenceYAML) error {
+	if p.Spec.Id != "" && p.Spec.Id != ref.TargetId {
+		return errConflictId
+	}
+	p.Spec.Id = ref.TargetId
+	return nil
+}
+
+// rollbackInit resets the project to its pre-initialization state.
+func (p *projectYAML) rollbackInit() { p.Spec.Id = "" }
+
+// parentRef returns the project's declared logical parent, if any.
+func (p *projectYAML) parentRef() *referenceYAML { return p.Spec.ParentRef }
+
+// sourceFile returns the input file this project's spec was loaded from.
+func (p *projectYAML) sourceFile() string { return p.srcFile }
+
+// setSourceFile records path as the project's input file, unless the
+// project already knows its own source file (stamped at construction time
+// from the include graph, see newProjectsBySpecs). See folderYAML.setSourceFile.
+func (p *projectYAML) setSourceFile(path string) {
+	if p.srcFile == "" {
+		p.srcFile = path
+	}
+}
+
+// signedBy returns the PGP key id that verifiably authored this project's
+// spec.
+func (p *projectYAML) signedBy() string { return p.signer }
+
+// setSignedBy records keyId as the project's verified signer, unless the
+// project already has its own signer (stamped at construction time from the
+// include graph). See folderYAML.setSignedBy.
+func (p *projectYAML) setSignedBy(keyId string) {
+	if p.signer == "" {
+		p.signer = keyId
+	}
+}
+
+// dump writes the project's string representation into buff.
+func (p *projectYAML) dump(ind int, buff io.Writer) error {
+	indent := strings.Repeat(" ", ind)
+	if _, err := fmt.Fprintf(buff, "%s%s.%s (\"%s\")\n", indent, Project, p.Spec.Id, p.Spec.DisplayName); err != nil {
+		return err
+	}
+	if err := dumpFields(ind+defaultIndentSize, buff, p.effectiveLabels, p.effectiveIamPolicy, p.fieldProv); err != nil {
+		return err
+	}
+	return dumpSigner(ind+defaultIndentSize, buff, p.resId(), p.signer)
+}
+
+// draw renders the project as a leaf node of the diagram.
+func (p *projectYAML) draw(d *diagram) error {
+	if err := d.renderer.BeginNode(p.resId(), Project, p.Spec.DisplayName); err != nil {
+		return err
+	}
+	return d.renderer.EndNode()
+}
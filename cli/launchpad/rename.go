@@ -0,0 +1,54 @@
+package launchpad
+
+import "fmt"
+
+// resIdRenamesKey is the top-level directive recognized by the loader: a
+// map from a colliding resId to the id assembly should register it under
+// instead, e.g. {"Folder.platform": "Folder.platform-shared"}.
+const resIdRenamesKey = "resIdRenames"
+
+// ResIdCollisionError reports two resources that assembled to the same
+// resId with no configured rename to disambiguate them.
+type ResIdCollisionError struct {
+	ResId        string
+	FileA, FileB string
+}
+
+func (e *ResIdCollisionError) Error() string {
+	fileA, fileB := e.FileA, e.FileB
+	if fileA == "" {
+		fileA = "<unknown source>"
+	}
+	if fileB == "" {
+		fileB = "<unknown source>"
+	}
+	return fmt.Sprintf(
+		"resId collision on %q between %s and %s; add a rename to disambiguate, e.g.:\n"+
+			"resIdRenames:\n  %s: %s-2",
+		e.ResId, fileA, fileB, e.ResId, e.ResId,
+	)
+}
+
+// extractResIdRenames pulls the resIdRenames directive out of doc, if
+// present, removing it so downstream YAML types never see it.
+func extractResIdRenames(doc map[string]interface{}) (map[string]string, error) {
+	v, ok := doc[resIdRenamesKey]
+	if !ok {
+		return nil, nil
+	}
+	delete(doc, resIdRenamesKey)
+
+	raw, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%q must be a map of resId to renamed resId", resIdRenamesKey)
+	}
+	renames := make(map[string]string, len(raw))
+	for k, rv := range raw {
+		s, ok := rv.(string)
+		if !ok {
+			return nil, fmt.Errorf("%q[%s] must be a string, got %T", resIdRenamesKey, k, rv)
+		}
+		renames[k] = s
+	}
+	return renames, nil
+}
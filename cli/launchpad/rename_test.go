@@ -0,0 +1,26 @@
+package launchpad
+
+import "testing"
+
+// TestRegisterResourceCollisionReportsBothSourceFiles exercises
+// ResIdCollisionError for a resId declared by two different source files:
+// large multi-team merges rely on FileA/FileB naming the two actual files
+// so someone can go disambiguate them with a resIdRenames entry.
+func TestRegisterResourceCollisionReportsBothSourceFiles(t *testing.T) {
+	f1 := &folderYAML{Spec: folderSpecYAML{Id: "dup"}, srcFile: "org.yaml"}
+	f2 := &folderYAML{Spec: folderSpecYAML{Id: "dup"}, srcFile: "teamB.yaml"}
+
+	ao := newAssembledOrg()
+	if err := ao.registerResource(f1); err != nil {
+		t.Fatalf("registerResource(f1): %v", err)
+	}
+
+	err := ao.registerResource(f2)
+	collision, ok := err.(*ResIdCollisionError)
+	if !ok {
+		t.Fatalf("registerResource(f2): expected *ResIdCollisionError, got %T: %v", err, err)
+	}
+	if collision.FileA != "org.yaml" || collision.FileB != "teamB.yaml" {
+		t.Fatalf("collision = %+v, want FileA=org.yaml FileB=teamB.yaml", collision)
+	}
+}
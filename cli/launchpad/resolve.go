@@ -0,0 +1,256 @@
+package launchpad
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ReferenceCycleError reports a cycle in the reference graph, e.g. folder A
+// parents folder B which in turn parents folder A.
+type ReferenceCycleError struct {
+	Cycle []string         // resIds on the cycle, in cycle order, repeating the first at the end.
+	Refs  []*referenceYAML // the referenceYAML that forms each edge of Cycle.
+}
+
+func (e *ReferenceCycleError) Error() string {
+	locs := make([]string, 0, len(e.Refs))
+	for _, r := range e.Refs {
+		locs = append(locs, fmt.Sprintf("%s:%d", r.SourceFile, r.Line))
+	}
+	return fmt.Sprintf("reference cycle detected: %s (%s)", strings.Join(e.Cycle, " -> "), strings.Join(locs, ", "))
+}
+
+// UnresolvedReferenceError reports a referenceYAML whose TargetId has no
+// matching resource anywhere in the assembled organization.
+type UnresolvedReferenceError struct {
+	Ref *referenceYAML
+}
+
+func (e *UnresolvedReferenceError) Error() string {
+	return fmt.Sprintf("%s:%d: unresolved reference to %s.%s", e.Ref.SourceFile, e.Ref.Line, e.Ref.TargetKind, e.Ref.TargetId)
+}
+
+// color is a three-color DFS marker: white (unvisited), gray (on the
+// current path), black (fully explored).
+type color int
+
+const (
+	white color = iota
+	gray
+	black
+)
+
+type referenceEdge struct {
+	from, to string
+	ref      *referenceYAML
+}
+
+// referenceGraph accumulates the reference edges discovered while walking
+// the spec, so they can be checked for cycles and dangling targets in one
+// pass once the whole tree has been assembled.
+type referenceGraph struct {
+	edges []referenceEdge
+}
+
+func (g *referenceGraph) add(from, to string, ref *referenceYAML) {
+	g.edges = append(g.edges, referenceEdge{from: from, to: to, ref: ref})
+}
+
+// check verifies every edge target exists in resources, then runs a
+// recursive three-color DFS to find reference cycles.
+func (g *referenceGraph) check(resources map[string]resourceHandler) error {
+	adj := map[string][]referenceEdge{}
+	for _, e := range g.edges {
+		if _, ok := resources[e.to]; !ok {
+			return &UnresolvedReferenceError{Ref: e.ref}
+		}
+		adj[e.from] = append(adj[e.from], e)
+	}
+
+	ids := make([]string, 0, len(resources))
+	for id := range resources {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids) // deterministic traversal order.
+
+	colors := map[string]color{}
+	var path []string
+	var pathRefs []*referenceYAML
+
+	var visit func(id string) error
+	visit = func(id string) error {
+		colors[id] = gray
+		path = append(path, id)
+		for _, e := range adj[id] {
+			switch colors[e.to] {
+			case white:
+				pathRefs = append(pathRefs, e.ref)
+				if err := visit(e.to); err != nil {
+					return err
+				}
+				pathRefs = pathRefs[:len(pathRefs)-1]
+			case gray:
+				start := indexOf(path, e.to)
+				cycle := append(append([]string{}, path[start:]...), e.to)
+				refs := append(append([]*referenceYAML{}, pathRefs[start:]...), e.ref)
+				return &ReferenceCycleError{Cycle: cycle, Refs: refs}
+			case black:
+				// fully explored elsewhere; cannot be part of a new cycle.
+			}
+		}
+		path = path[:len(path)-1]
+		colors[id] = black
+		return nil
+	}
+
+	for _, id := range ids {
+		if colors[id] == white {
+			if err := visit(id); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func indexOf(s []string, v string) int {
+	for i, e := range s {
+		if e == v {
+			return i
+		}
+	}
+	return -1
+}
+
+// hasUnsetId reports whether r has not yet been assigned an id, i.e. its
+// resId is still "<Kind>.".
+func hasUnsetId(r resourceHandler) bool {
+	return strings.HasSuffix(r.resId(), ".")
+}
+
+// initializeByRef initializes target through child's reference to it,
+// recording the child -> target reference edge for later cycle/dangling-
+// target detection and the target itself for rollback if that check fails.
+func (ao *assembledOrg) initializeByRef(child, target resourceHandler, ref *referenceYAML) error {
+	wasUnset := hasUnsetId(target)
+
+	ao.refGraph.add(child.resId(), fmt.Sprintf("%s.%s", ref.TargetKind, ref.TargetId), ref)
+	if err := target.initializeByRef(ref); err != nil {
+		return err
+	}
+	if wasUnset {
+		ao.pendingInit = append(ao.pendingInit, target)
+	}
+	return nil
+}
+
+// linkParentRefs walks every already-registered resource looking for a
+// declared parentRef (see folderSpecYAML.ParentRef), resolves it against
+// ao.resources, and wires it into the reference graph: the referenced
+// parent is initialized/confirmed via initializeByRef, the child is moved
+// out of wherever it was physically nested and attached under its logical
+// parent via resolveReferences. This is what makes a parentage cycle (e.g.
+// folder A declares folder B as parent, and B declares A) reachable: plain
+// YAML nesting can never produce one on its own, since it forms a tree.
+func (ao *assembledOrg) linkParentRefs() error {
+	ids := make([]string, 0, len(ao.resources))
+	for id := range ao.resources {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids) // deterministic processing order.
+
+	for _, id := range ids {
+		child := ao.resources[id]
+		ref := child.parentRef()
+		if ref == nil {
+			continue
+		}
+
+		targetId := fmt.Sprintf("%s.%s", ref.TargetKind, ref.TargetId)
+		target, ok := ao.resources[targetId]
+		if !ok {
+			return &UnresolvedReferenceError{Ref: ref}
+		}
+
+		if err := ao.initializeByRef(child, target, ref); err != nil {
+			return err
+		}
+		ao.detachFromPhysicalParent(child)
+		if err := target.resolveReferences([]resourceHandler{child}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// detachFromPhysicalParent removes child from the subFolders/projects slice
+// of whatever resource it was declared nested under, so a resource attached
+// via parentRef ends up in exactly one place in the assembled tree.
+func (ao *assembledOrg) detachFromPhysicalParent(child resourceHandler) {
+	var parentKind, parentId string
+	switch c := child.(type) {
+	case *folderYAML:
+		parentKind, parentId = c.parentKind, c.parentId
+	case *projectYAML:
+		parentKind, parentId = c.parentKind, c.parentId
+	default:
+		return
+	}
+
+	parent, ok := ao.resources[fmt.Sprintf("%s.%s", parentKind, parentId)]
+	if !ok {
+		return
+	}
+	switch p := parent.(type) {
+	case *orgYAML:
+		p.subFolders = removeFolder(p.subFolders, child)
+	case *folderYAML:
+		p.subFolders = removeFolder(p.subFolders, child)
+		p.projects = removeProject(p.projects, child)
+	}
+}
+
+func removeFolder(fs folders, child resourceHandler) folders {
+	out := fs[:0]
+	for _, f := range fs {
+		if resourceHandler(f) != child {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+func removeProject(ps projects, child resourceHandler) projects {
+	out := ps[:0]
+	for _, p := range ps {
+		if resourceHandler(p) != child {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// resolveAndCheckReferences runs the reference resolution pass: it links
+// every declared parentRef, then checks the reference graph accumulated by
+// initializeByRef for cycles and dangling targets. On failure it rolls back
+// every partial initializeByRef mutation so Spec.Id is left unset rather
+// than dangling.
+func (ao *assembledOrg) resolveAndCheckReferences() error {
+	if err := ao.linkParentRefs(); err != nil {
+		for _, r := range ao.pendingInit {
+			r.rollbackInit()
+		}
+		ao.pendingInit = nil
+		return err
+	}
+	if err := ao.refGraph.check(ao.resources); err != nil {
+		for _, r := range ao.pendingInit {
+			r.rollbackInit()
+		}
+		ao.pendingInit = nil
+		return err
+	}
+	ao.pendingInit = nil
+	return nil
+}
@@ -0,0 +1,62 @@
+package launchpad
+
+import "testing"
+
+// TestResolveAndCheckReferencesDetectsParentRefCycle exercises the real
+// pipeline wiring (linkParentRefs -> initializeByRef -> refGraph.check):
+// two folders nested as ordinary siblings under the same org, each
+// declaring the other as its logical parent via parentRef, form a cycle
+// that plain YAML nesting alone could never produce.
+func TestResolveAndCheckReferencesDetectsParentRefCycle(t *testing.T) {
+	f1 := &folderYAML{
+		Spec:       folderSpecYAML{Id: "f1", ParentRef: &referenceYAML{TargetKind: Folder, TargetId: "f2"}},
+		parentKind: Organization,
+		parentId:   "o1",
+	}
+	f2 := &folderYAML{
+		Spec:       folderSpecYAML{Id: "f2", ParentRef: &referenceYAML{TargetKind: Folder, TargetId: "f1"}},
+		parentKind: Organization,
+		parentId:   "o1",
+	}
+	o := &orgYAML{Spec: orgSpecYAML{Id: "o1"}, subFolders: folders{f1, f2}}
+
+	ao := newAssembledOrg()
+	if err := o.addToOrg(ao); err != nil {
+		t.Fatalf("addToOrg: %v", err)
+	}
+
+	err := ao.resolveAndCheckReferences()
+	if err == nil {
+		t.Fatal("resolveAndCheckReferences: expected a reference cycle error, got nil")
+	}
+	if _, ok := err.(*ReferenceCycleError); !ok {
+		t.Fatalf("resolveAndCheckReferences: expected *ReferenceCycleError, got %T: %v", err, err)
+	}
+}
+
+// TestResolveAndCheckReferencesLinksParentRef confirms the non-cycle case:
+// a folder's parentRef moves it under its declared logical parent.
+func TestResolveAndCheckReferencesLinksParentRef(t *testing.T) {
+	child := &folderYAML{
+		Spec:       folderSpecYAML{Id: "child", ParentRef: &referenceYAML{TargetKind: Folder, TargetId: "real-parent"}},
+		parentKind: Organization,
+		parentId:   "o1",
+	}
+	realParent := &folderYAML{Spec: folderSpecYAML{Id: "real-parent"}, parentKind: Organization, parentId: "o1"}
+	o := &orgYAML{Spec: orgSpecYAML{Id: "o1"}, subFolders: folders{child, realParent}}
+
+	ao := newAssembledOrg()
+	if err := o.addToOrg(ao); err != nil {
+		t.Fatalf("addToOrg: %v", err)
+	}
+	if err := ao.resolveAndCheckReferences(); err != nil {
+		t.Fatalf("resolveAndCheckReferences: %v", err)
+	}
+
+	if len(o.subFolders) != 1 || o.subFolders[0].resId() != realParent.resId() {
+		t.Fatalf("expected child to be detached from org, subFolders = %v", o.subFolders)
+	}
+	if len(realParent.subFolders) != 1 || realParent.subFolders[0].resId() != child.resId() {
+		t.Fatalf("expected child to be attached under real-parent, subFolders = %v", realParent.subFolders)
+	}
+}
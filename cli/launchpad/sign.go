@@ -0,0 +1,202 @@
+package launchpad
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"gopkg.in/yaml.v3"
+)
+
+// LoadTrustedKeys reads a repo-level trustedKeys config file (a bare
+// `trustedKeys:` document, independent of any single org spec) for use as
+// VerifyOptions.TrustedKeys.
+func LoadTrustedKeys(path string) ([]trustedKey, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	var doc map[string]interface{}
+	if err := yaml.Unmarshal(raw, &doc); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	keys, err := extractTrustedKeys(doc)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return keys, nil
+}
+
+// trustedKeysKey is the top-level directive listing the PGP keys allowed to
+// author signed specs.
+const trustedKeysKey = "trustedKeys"
+
+// trustedKey pairs an ASCII-armored PGP public key with the resource kinds
+// it is allowed to author. An empty AllowedKinds means any kind.
+type trustedKey struct {
+	KeyId        string   `yaml:"keyId"`
+	PublicKey    string   `yaml:"publicKey"`
+	AllowedKinds []string `yaml:"allowedKinds,omitempty"`
+}
+
+// VerifyOptions configures detached-signature verification. Verification
+// only runs when TrustedKeys is non-empty; InsecureSkipVerify bypasses it
+// even then and must be set explicitly by the caller, never by default.
+type VerifyOptions struct {
+	TrustedKeys        []trustedKey
+	InsecureSkipVerify bool
+}
+
+// SignatureRejection reports one file that failed signature verification.
+type SignatureRejection struct {
+	File   string
+	Reason string
+}
+
+// SignatureVerificationError aggregates every file rejected during a single
+// assembly attempt, so a user sees every problem at once rather than one at
+// a time.
+type SignatureVerificationError struct {
+	Rejections []SignatureRejection
+}
+
+func (e *SignatureVerificationError) Error() string {
+	lines := make([]string, 0, len(e.Rejections))
+	for _, r := range e.Rejections {
+		lines = append(lines, fmt.Sprintf("  %s: %s", r.File, r.Reason))
+	}
+	return fmt.Sprintf("signature verification failed for %d file(s):\n%s", len(e.Rejections), strings.Join(lines, "\n"))
+}
+
+// extractTrustedKeys pulls the trustedKeys directive out of doc, if
+// present, removing it so downstream YAML types never see it.
+func extractTrustedKeys(doc map[string]interface{}) ([]trustedKey, error) {
+	v, ok := doc[trustedKeysKey]
+	if !ok {
+		return nil, nil
+	}
+	delete(doc, trustedKeysKey)
+
+	raw, err := yaml.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var keys []trustedKey
+	if err := yaml.Unmarshal(raw, &keys); err != nil {
+		return nil, fmt.Errorf("%q: %w", trustedKeysKey, err)
+	}
+	return keys, nil
+}
+
+// verifyState accumulates signature verification results across the whole
+// include graph of a single LoadSpec call.
+type verifyState struct {
+	opts       VerifyOptions
+	keyring    openpgp.EntityList
+	rejections []SignatureRejection
+	signedBy   map[string]string // file path -> verified signer key id.
+}
+
+func newVerifyState(opts VerifyOptions) (*verifyState, error) {
+	if opts.InsecureSkipVerify {
+		log.Printf("WARNING: signature verification is disabled (--insecure-skip-verify); spec authorship is not being checked")
+	}
+	if len(opts.TrustedKeys) == 0 {
+		return &verifyState{opts: opts, signedBy: map[string]string{}}, nil
+	}
+
+	var keyring openpgp.EntityList
+	for _, tk := range opts.TrustedKeys {
+		entities, err := openpgp.ReadArmoredKeyRing(strings.NewReader(tk.PublicKey))
+		if err != nil {
+			return nil, fmt.Errorf("trustedKeys: parsing public key for %s: %w", tk.KeyId, err)
+		}
+		keyring = append(keyring, entities...)
+	}
+	return &verifyState{opts: opts, keyring: keyring, signedBy: map[string]string{}}, nil
+}
+
+// verify checks path's detached signature, recording either the verified
+// signer or a rejection. It never aborts the caller's traversal: every file
+// in the include graph is checked so the final error can report all of
+// them at once.
+func (vs *verifyState) verify(path string, raw []byte, kind string) {
+	if vs.opts.InsecureSkipVerify || len(vs.opts.TrustedKeys) == 0 {
+		return
+	}
+
+	keyId, err := verifyDetachedSignature(path, raw, vs.keyring, vs.opts.TrustedKeys, kind)
+	if err != nil {
+		vs.rejections = append(vs.rejections, SignatureRejection{File: path, Reason: err.Error()})
+		return
+	}
+	vs.signedBy[path] = keyId
+}
+
+// err returns the aggregate SignatureVerificationError if any file was
+// rejected, nil otherwise.
+func (vs *verifyState) err() error {
+	if len(vs.rejections) == 0 {
+		return nil
+	}
+	return &SignatureVerificationError{Rejections: vs.rejections}
+}
+
+// verifyDetachedSignature verifies path's sibling <path>.sig against
+// keyring, then checks the signer is trusted to author kind.
+func verifyDetachedSignature(path string, raw []byte, keyring openpgp.EntityList, trusted []trustedKey, kind string) (string, error) {
+	sigPath := path + ".sig"
+	sigFile, err := os.Open(sigPath)
+	if err != nil {
+		return "", fmt.Errorf("missing detached signature %s", sigPath)
+	}
+	defer sigFile.Close()
+
+	signer, err := openpgp.CheckArmoredDetachedSignature(keyring, bytes.NewReader(raw), sigFile, nil)
+	if err != nil {
+		return "", fmt.Errorf("signature does not verify: %w", err)
+	}
+
+	keyId := signer.PrimaryKey.KeyIdString()
+	tk, ok := trustedKeyByID(trusted, keyId)
+	if !ok {
+		return "", fmt.Errorf("key %s is not in trustedKeys", keyId)
+	}
+	if len(tk.AllowedKinds) > 0 && !containsStr(tk.AllowedKinds, kind) {
+		return "", fmt.Errorf("key %s is not authorized to author %s resources", keyId, kind)
+	}
+	return keyId, nil
+}
+
+func trustedKeyByID(trusted []trustedKey, keyId string) (trustedKey, bool) {
+	for _, tk := range trusted {
+		if tk.KeyId == keyId {
+			return tk, true
+		}
+	}
+	return trustedKey{}, false
+}
+
+// dumpSigner writes a resource's verified signer, if any, into buff for
+// dump's audit output, e.g. "Folder.platform was authored by ABCD1234".
+func dumpSigner(ind int, buff io.Writer, resId, keyId string) error {
+	if keyId == "" {
+		return nil
+	}
+	indent := strings.Repeat(" ", ind)
+	_, err := fmt.Fprintf(buff, "%s%s was authored by %s\n", indent, resId, keyId)
+	return err
+}
+
+func containsStr(ss []string, s string) bool {
+	for _, v := range ss {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,216 @@
+package launchpad
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+)
+
+// inputsKey is the top-level directive declaring a spec's template inputs.
+const inputsKey = "inputs"
+
+// inputSpec declares one templated input: its name, type, default value,
+// and whether a caller must supply it.
+type inputSpec struct {
+	Name     string      `yaml:"name"`
+	Type     string      `yaml:"type"` // "string" (default), "bool", or "int".
+	Default  interface{} `yaml:"default,omitempty"`
+	Required bool        `yaml:"required,omitempty"`
+}
+
+// templateData is the root object exposed to every templated spec.
+type templateData struct {
+	Inputs map[string]interface{}
+}
+
+// TemplateOptions configures ExpandSpec: --set overrides and the env vars a
+// spec is allowed to read via the env template func.
+type TemplateOptions struct {
+	Set          map[string]string
+	EnvWhitelist []string
+	Verify       VerifyOptions
+}
+
+func (o TemplateOptions) envAllowed(name string) bool {
+	for _, e := range o.EnvWhitelist {
+		if e == name {
+			return true
+		}
+	}
+	return false
+}
+
+// ExpandSpec renders the Go-template directives in raw (sourced from path)
+// using inputs declared by the document's own `inputs:` block, `--set`
+// overrides from opts, and the env/include/toYaml helper funcs, returning
+// the expanded YAML ready to be unmarshaled.
+func ExpandSpec(path string, raw []byte, opts TemplateOptions) ([]byte, error) {
+	// The inputs declaration is extracted from a restricted top-level scan
+	// rather than a full yaml.Unmarshal of raw: the rest of the document is
+	// expected to contain unrendered `{{ ... }}` template actions (that's
+	// the whole point of this feature), and `{{ ... }}` is not valid YAML
+	// scalar syntax, so a full parse would fail before templating ever runs.
+	specs, err := extractInputSpecs(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	values, err := resolveInputValues(specs, opts.Set)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	tmpl, err := template.New(filepath.Base(path)).Funcs(templateFuncs(path, opts)).Parse(string(raw))
+	if err != nil {
+		return nil, fmt.Errorf("%s: template error: %w", path, err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, templateData{Inputs: values}); err != nil {
+		return nil, fmt.Errorf("%s: template error: %w", path, err)
+	}
+	return buf.Bytes(), nil
+}
+
+// templateFuncs builds the helper funcs available to a spec being expanded
+// from path: env (whitelist-checked), include (renders another spec file
+// relative to path and inlines its text), and toYaml.
+func templateFuncs(path string, opts TemplateOptions) template.FuncMap {
+	return template.FuncMap{
+		"env": func(name string) (string, error) {
+			if !opts.envAllowed(name) {
+				return "", fmt.Errorf("env %q is not in the allowed env whitelist", name)
+			}
+			return os.Getenv(name), nil
+		},
+		"toYaml": func(v interface{}) (string, error) {
+			b, err := yaml.Marshal(v)
+			if err != nil {
+				return "", err
+			}
+			return strings.TrimRight(string(b), "\n"), nil
+		},
+		"include": func(includePath string, data interface{}) (string, error) {
+			resolved := filepath.Join(filepath.Dir(path), includePath)
+			raw, err := os.ReadFile(resolved)
+			if err != nil {
+				return "", err
+			}
+			expanded, err := ExpandSpec(resolved, raw, opts)
+			if err != nil {
+				return "", err
+			}
+			return string(expanded), nil
+		},
+	}
+}
+
+// extractInputSpecs pulls the `inputs:` directive out of raw and parses it,
+// without requiring the rest of raw to be valid YAML yet (see ExpandSpec).
+func extractInputSpecs(raw []byte) ([]inputSpec, error) {
+	block, ok := topLevelYAMLBlock(raw, inputsKey)
+	if !ok {
+		return nil, nil
+	}
+
+	var doc struct {
+		Inputs []inputSpec `yaml:"inputs"`
+	}
+	if err := yaml.Unmarshal(block, &doc); err != nil {
+		return nil, fmt.Errorf("%q: %w", inputsKey, err)
+	}
+	return doc.Inputs, nil
+}
+
+// topLevelYAMLBlock extracts the raw text of a top-level "key:" mapping
+// entry from raw — the "key:" line itself plus every line indented under
+// it — without parsing the rest of the document, which may still contain
+// unrendered template actions. It assumes raw uses block (not flow) style
+// at the top level, which holds for every spec document this loader reads.
+func topLevelYAMLBlock(raw []byte, key string) ([]byte, bool) {
+	lines := strings.Split(string(raw), "\n")
+	prefix := key + ":"
+
+	var block []string
+	found := false
+	for _, line := range lines {
+		if !found {
+			if line == prefix || strings.HasPrefix(line, prefix+" ") {
+				found = true
+				block = append(block, line)
+			}
+			continue
+		}
+		if line == "" || strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t") {
+			block = append(block, line)
+			continue
+		}
+		break
+	}
+	if !found {
+		return nil, false
+	}
+	return []byte(strings.Join(block, "\n")), true
+}
+
+// resolveInputValues computes the effective value of every declared input
+// from (in priority order) --set overrides and input defaults, erroring on
+// an unset required input or a --set targeting an undeclared input.
+func resolveInputValues(specs []inputSpec, set map[string]string) (map[string]interface{}, error) {
+	declared := make(map[string]bool, len(specs))
+	values := make(map[string]interface{}, len(specs))
+
+	for _, s := range specs {
+		declared[s.Name] = true
+		if raw, ok := set[s.Name]; ok {
+			v, err := convertInputValue(s, raw)
+			if err != nil {
+				return nil, err
+			}
+			values[s.Name] = v
+			continue
+		}
+		if s.Default != nil {
+			values[s.Name] = s.Default
+			continue
+		}
+		if s.Required {
+			return nil, fmt.Errorf("required input %q was not provided", s.Name)
+		}
+	}
+
+	for k := range set {
+		if !declared[k] {
+			return nil, fmt.Errorf("--set %s: no input named %q is declared", k, k)
+		}
+	}
+	return values, nil
+}
+
+// convertInputValue parses raw (a --set value, always a string) according
+// to s.Type.
+func convertInputValue(s inputSpec, raw string) (interface{}, error) {
+	switch s.Type {
+	case "", "string":
+		return raw, nil
+	case "bool":
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, fmt.Errorf("input %q: %q is not a bool", s.Name, raw)
+		}
+		return b, nil
+	case "int":
+		i, err := strconv.Atoi(raw)
+		if err != nil {
+			return nil, fmt.Errorf("input %q: %q is not an int", s.Name, raw)
+		}
+		return i, nil
+	default:
+		return nil, fmt.Errorf("input %q: unsupported type %q", s.Name, s.Type)
+	}
+}
@@ -0,0 +1,50 @@
+package launchpad
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestExpandSpecWithUnquotedTemplateAction reproduces the reviewer's repro:
+// an inputs: pre-pass that required the whole raw document to already be
+// valid YAML broke on completely ordinary usage, since `{{ .Inputs.env }}`
+// is not valid YAML scalar syntax.
+func TestExpandSpecWithUnquotedTemplateAction(t *testing.T) {
+	raw := []byte(`apiVersion: v1
+kind: Organization
+inputs:
+  - name: env
+    default: prod
+spec:
+  id: "123"
+  displayName: {{ .Inputs.env }}-org
+`)
+
+	out, err := ExpandSpec("org.yaml", raw, TemplateOptions{})
+	if err != nil {
+		t.Fatalf("ExpandSpec: %v", err)
+	}
+	if !strings.Contains(string(out), "prod-org") {
+		t.Fatalf("ExpandSpec output = %q, want it to contain %q", out, "prod-org")
+	}
+}
+
+func TestExtractInputSpecsIgnoresUnrenderedTemplateActionsElsewhere(t *testing.T) {
+	raw := []byte(`apiVersion: v1
+kind: Organization
+inputs:
+  - name: env
+    type: string
+    required: true
+spec:
+  id: {{ .Inputs.env }}
+`)
+
+	specs, err := extractInputSpecs(raw)
+	if err != nil {
+		t.Fatalf("extractInputSpecs: %v", err)
+	}
+	if len(specs) != 1 || specs[0].Name != "env" || !specs[0].Required {
+		t.Fatalf("extractInputSpecs = %+v, want one required input named %q", specs, "env")
+	}
+}
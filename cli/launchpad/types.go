@@ -0,0 +1,139 @@
+package launchpad
+
+import (
+	"errors"
+	"io"
+)
+
+// Resource kind discriminators, used both as YAML `kind` values and as the
+// prefix of a resId().
+const (
+	Organization = "Organization"
+	Folder       = "Folder"
+	Project      = "Project"
+)
+
+// defaultIndentSize is the number of spaces each nesting level adds to dump
+// output.
+const defaultIndentSize = 2
+
+var errValidationFailed = errors.New("validation failed")
+
+// headerYAML holds the fields common to every top-level spec document.
+type headerYAML struct {
+	APIVersion string `yaml:"apiVersion"`
+	Kind       string `yaml:"kind"`
+}
+
+// referenceYAML is a pointer from one resource to another, used to
+// initialize a resource (e.g. an org or folder) purely from the reference
+// made to it by a child, without requiring an explicit spec document.
+type referenceYAML struct {
+	TargetKind string `yaml:"kind"`
+	TargetId   string `yaml:"id"`
+
+	// SourceFile and Line are printed by ReferenceCycleError and
+	// UnresolvedReferenceError to locate this reference in the input YAML.
+	// They are not read from YAML and the loader does not set them, so a
+	// reference loaded from a spec reports an empty file and line 0.
+	SourceFile string `yaml:"-"`
+	Line       int    `yaml:"-"`
+}
+
+// resourceHandler is implemented by every {org,folder,project}YAML so the
+// assembler can treat the hierarchy uniformly.
+type resourceHandler interface {
+	// resId returns an internal referencable id of the form "<Kind>.<id>".
+	resId() string
+
+	// validate ensures input YAML fields are correct.
+	validate() error
+
+	// addToOrg adds the resource (and, recursively, its children) into the
+	// assembled organization.
+	addToOrg(ao *assembledOrg) error
+
+	// resolveReferences processes references made to this resource by its
+	// children.
+	resolveReferences(refs []resourceHandler) error
+
+	// initializeByRef initializes a resource through another resource's
+	// reference, when no explicit spec document exists for it.
+	initializeByRef(ref *referenceYAML) error
+
+	// parentRef returns the reference this resource's own spec declares to
+	// its logical parent, or nil if it was declared nested under its parent
+	// in the usual way and has no separate parentRef. The root org always
+	// returns nil.
+	parentRef() *referenceYAML
+
+	// rollbackInit undoes a successful initializeByRef, restoring the
+	// resource to its pre-initialization (unset id) state. It is a no-op on
+	// a resource that was never initialized by reference.
+	rollbackInit()
+
+	// Note: there is no mergeFields here. Same-resId occurrences across
+	// input files are merged only at the YAML level, by include.go's
+	// mergeMaps/mergeSequences, before anything is unmarshaled into a
+	// resourceHandler; see propagate.go for which occurrences that covers.
+
+	// sourceFile returns the path of the input file this resource's spec
+	// was loaded from, for collision and audit reporting. Empty if unknown.
+	sourceFile() string
+
+	// signedBy returns the PGP key id that verifiably authored this
+	// resource's spec, or "" if signature verification was not performed.
+	signedBy() string
+
+	// dump writes the resource's string representation into buff.
+	dump(ind int, buff io.Writer) error
+
+	// draw renders the resource (and its children) into d.
+	draw(d *diagram) error
+}
+
+// assembledOrg is the fully resolved organization tree produced by loading
+// and merging every input spec document.
+type assembledOrg struct {
+	resources map[string]resourceHandler
+
+	refGraph    *referenceGraph   // edges accumulated by initializeByRef.
+	pendingInit []resourceHandler // resources initialized by ref, not yet confirmed cycle-free.
+
+	// renames is the `resIdRenames:` config consulted by registerResource
+	// before it would otherwise report a collision.
+	renames map[string]string
+}
+
+// newAssembledOrg returns an empty assembledOrg ready for resources to be
+// registered into it.
+func newAssembledOrg() *assembledOrg {
+	return &assembledOrg{resources: map[string]resourceHandler{}, refGraph: &referenceGraph{}}
+}
+
+// SetResIdRenames configures the resId collision resolution consulted by
+// registerResource, e.g. {"Folder.platform": "Folder.platform-shared"}.
+func (ao *assembledOrg) SetResIdRenames(renames map[string]string) {
+	ao.renames = renames
+}
+
+// registerResource records r under its resId. If resId collides with an
+// already-registered, different resource, it is consulted against the
+// configured renames and r is registered under the renamed id instead; with
+// no rename configured, or a renamed id that is itself taken, the collision
+// is reported as a ResIdCollisionError.
+func (ao *assembledOrg) registerResource(r resourceHandler) error {
+	id := r.resId()
+	if existing, ok := ao.resources[id]; ok && existing != r {
+		renamed, ok := ao.renames[id]
+		if !ok {
+			return &ResIdCollisionError{ResId: id, FileA: existing.sourceFile(), FileB: r.sourceFile()}
+		}
+		id = renamed
+		if existing, ok := ao.resources[id]; ok && existing != r {
+			return &ResIdCollisionError{ResId: id, FileA: existing.sourceFile(), FileB: r.sourceFile()}
+		}
+	}
+	ao.resources[id] = r
+	return nil
+}